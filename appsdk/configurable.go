@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+	"github.com/edgexfoundry/app-functions-sdk-go/internal/bootstrap/container"
 	"github.com/edgexfoundry/app-functions-sdk-go/pkg/transforms"
 	"github.com/edgexfoundry/app-functions-sdk-go/pkg/util"
 	"github.com/edgexfoundry/go-mod-core-contracts/models"
@@ -41,6 +43,20 @@ const (
 	AutoReconnect    = "autoreconnect"
 	DeviceName       = "devicename"
 	ReadingName      = "readingname"
+	ResponseTopic    = "responsetopic"
+	CommandName      = "commandname"
+	Method           = "method"
+	BodyTemplate     = "bodytemplate"
+	Sender           = "sender"
+	Category         = "category"
+	Severity         = "severity"
+	Labels           = "labels"
+	ContentType      = "contenttype"
+	Mode             = "mode"
+	BatchThreshold   = "batchthreshold"
+	TimeInterval     = "timeinterval"
+	Expression       = "expression"
+	Match            = "match"
 )
 
 // AppFunctionsSDKConfigurable contains the helper functions that return the function pointers for building the configurable function pipeline.
@@ -339,6 +355,344 @@ func (dynamic AppFunctionsSDKConfigurable) MQTTSend(parameters map[string]string
 	return sender.MQTTSend
 }
 
+// PublishMQTTResponse publishes data from the previous function to the specified MQTT broker on a
+// topic formed by appending the correlation ID of the triggering request to the configured
+// response topic prefix, e.g. `edgex/pipeline/response/<correlation-id>`. If no previous function
+// exists, then the event that triggered the pipeline will be used. This is intended to be paired
+// with the external-mqtt trigger to implement request/reply pipelines.
+// This function is a configuration function and returns a function pointer.
+func (dynamic AppFunctionsSDKConfigurable) PublishMQTTResponse(parameters map[string]string, addr models.Addressable) appcontext.AppFunction {
+	var err error
+	qos := 0
+	retain := false
+	autoreconnect := false
+	// optional string params
+	cert := parameters[Cert]
+	key := parameters[Key]
+
+	responseTopic, ok := parameters[ResponseTopic]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + ResponseTopic)
+		return nil
+	}
+	responseTopic = strings.TrimSpace(responseTopic)
+
+	qosVal, ok := parameters[Qos]
+	if ok {
+		qos, err = strconv.Atoi(qosVal)
+		if err != nil {
+			dynamic.Sdk.LoggingClient.Error("Unable to parse " + Qos + " value")
+			return nil
+		}
+	}
+	retainVal, ok := parameters[Retain]
+	if ok {
+		retain, err = strconv.ParseBool(retainVal)
+		if err != nil {
+			dynamic.Sdk.LoggingClient.Error("Unable to parse " + Retain + " value")
+			return nil
+		}
+	}
+	autoreconnectVal, ok := parameters[AutoReconnect]
+	if ok {
+		autoreconnect, err = strconv.ParseBool(autoreconnectVal)
+		if err != nil {
+			dynamic.Sdk.LoggingClient.Error("Unable to parse " + AutoReconnect + " value")
+			return nil
+		}
+	}
+	dynamic.Sdk.LoggingClient.Debug("MQTT Publish Response Parameters", "Address", addr, ResponseTopic, responseTopic, Qos, qosVal, Retain, retainVal, AutoReconnect, autoreconnectVal, Cert, cert, Key, key)
+
+	var pair *transforms.KeyCertPair
+
+	if len(cert) > 0 && len(key) > 0 {
+		pair = &transforms.KeyCertPair{
+			CertFile: cert,
+			KeyFile:  key,
+		}
+	}
+
+	// PersistOnError is optional and is false by default.
+	persistOnError := false
+	value, ok := parameters[PersistOnError]
+	if ok {
+		persistOnError, err = strconv.ParseBool(value)
+		if err != nil {
+			dynamic.Sdk.LoggingClient.Error(fmt.Sprintf("Could not parse '%s' to a bool for '%s' parameter", value, PersistOnError), "error", err)
+			return nil
+		}
+	}
+
+	mqttconfig := transforms.NewMqttConfig()
+	mqttconfig.SetQos(byte(qos))
+	mqttconfig.SetRetain(retain)
+	mqttconfig.SetAutoreconnect(autoreconnect)
+	publisher := transforms.NewMQTTResponsePublisher(dynamic.Sdk.LoggingClient, addr, responseTopic, pair, mqttconfig, persistOnError)
+	return publisher.PublishMQTTResponse
+}
+
+// IssueCoreCommand issues a device command, via the CommandClient resolved through DI, against the
+// device and command identified by DeviceName and CommandName. Method specifies whether the
+// command is issued as a GET or PUT; for PUT, the optional BodyTemplate is rendered against the
+// Event/Reading that triggered the pipeline (e.g. `{{.Value}}`) to build the request body. The
+// command's response is passed to the next function in the pipeline.
+// This function is a configuration function and returns a function pointer.
+func (dynamic AppFunctionsSDKConfigurable) IssueCoreCommand(parameters map[string]string) appcontext.AppFunction {
+	deviceName, ok := parameters[DeviceName]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + DeviceName)
+		return nil
+	}
+	commandName, ok := parameters[CommandName]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + CommandName)
+		return nil
+	}
+	method, ok := parameters[Method]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + Method)
+		return nil
+	}
+
+	// BodyTemplate is optional and only used for PUT requests.
+	bodyTemplate := parameters[BodyTemplate]
+
+	commandClient := container.CommandClientFrom(dynamic.Sdk.dic.Get)
+	if commandClient == nil {
+		dynamic.Sdk.LoggingClient.Error("CommandClient is not available; is the Command client configured?")
+		return nil
+	}
+
+	dynamic.Sdk.LoggingClient.Debug("IssueCoreCommand Parameters", DeviceName, deviceName, CommandName, commandName, Method, method)
+
+	transform := transforms.CoreCommand{
+		DeviceName:    strings.TrimSpace(deviceName),
+		CommandName:   strings.TrimSpace(commandName),
+		Method:        strings.TrimSpace(method),
+		BodyTemplate:  bodyTemplate,
+		CommandClient: commandClient,
+	}
+	return transform.IssueCoreCommand
+}
+
+// SendNotification sends a notification, built from the pipeline's payload, to the EdgeX
+// notifications service via the NotificationsClient resolved through DI. Sender, Category and
+// Severity are passed through to the EdgeX Notification model. Labels is a comma-separated list.
+// BodyTemplate is rendered with `{{.}}` referring to the data received from the previous function
+// (or the triggering Event/Reading if there is none) to build the notification's content.
+// PersistOnError behaves identically to HTTPPost/MQTTSend, routing failed sends through the
+// store-and-forward subsystem for retry.
+// This function is a configuration function and returns a function pointer.
+func (dynamic AppFunctionsSDKConfigurable) SendNotification(parameters map[string]string) appcontext.AppFunction {
+	var err error
+
+	sender, ok := parameters[Sender]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + Sender)
+		return nil
+	}
+	category, ok := parameters[Category]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + Category)
+		return nil
+	}
+	severity, ok := parameters[Severity]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + Severity)
+		return nil
+	}
+	contentType, ok := parameters[ContentType]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + ContentType)
+		return nil
+	}
+
+	// Labels and BodyTemplate are optional.
+	labelsCleaned := util.DeleteEmptyAndTrim(strings.FieldsFunc(parameters[Labels], util.SplitComma))
+	bodyTemplate := parameters[BodyTemplate]
+
+	// PersistOnError is optional and is false by default.
+	persistOnError := false
+	value, ok := parameters[PersistOnError]
+	if ok {
+		persistOnError, err = strconv.ParseBool(value)
+		if err != nil {
+			dynamic.Sdk.LoggingClient.Error(fmt.Sprintf("Could not parse '%s' to a bool for '%s' parameter", value, PersistOnError), "error", err)
+			return nil
+		}
+	}
+
+	notificationsClient := container.NotificationsClientFrom(dynamic.Sdk.dic.Get)
+	if notificationsClient == nil {
+		dynamic.Sdk.LoggingClient.Error("NotificationsClient is not available; is the Notifications client configured?")
+		return nil
+	}
+
+	dynamic.Sdk.LoggingClient.Debug("SendNotification Parameters", Sender, sender, Category, category, Severity, severity, ContentType, contentType, Labels, strings.Join(labelsCleaned, ","))
+
+	transform := transforms.Notification{
+		Sender:              strings.TrimSpace(sender),
+		Category:            strings.TrimSpace(category),
+		Severity:            strings.TrimSpace(severity),
+		ContentType:         strings.TrimSpace(contentType),
+		Labels:              labelsCleaned,
+		BodyTemplate:        bodyTemplate,
+		PersistOnError:      persistOnError,
+		NotificationsClient: notificationsClient,
+	}
+	return transform.SendNotification
+}
+
+// Batch buffers inbound payloads and only forwards them to the next pipeline stage once
+// BatchThreshold items have accumulated, TimeInterval has elapsed, or (for "ByTimeOrSize")
+// whichever comes first. The background timer started for "ByTime"/"ByTimeOrSize" is tied to the
+// SDK's shutdown context and is stopped when the SDK shuts down; a batch that is only partially
+// filled at that point is logged and then dropped, not forwarded, since shutdown has no pipeline
+// execution left to forward it through.
+// This function is a configuration function and returns a function pointer.
+func (dynamic AppFunctionsSDKConfigurable) Batch(parameters map[string]string) appcontext.AppFunction {
+	mode, ok := parameters[Mode]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + Mode)
+		return nil
+	}
+	mode = strings.TrimSpace(mode)
+
+	batchMode := transforms.BatchMode(mode)
+	if batchMode != transforms.BatchBySize && batchMode != transforms.BatchByTime && batchMode != transforms.BatchByTimeOrSize {
+		dynamic.Sdk.LoggingClient.Error(fmt.Sprintf("Invalid '%s' value of '%s'; must be BySize, ByTime or ByTimeOrSize", Mode, mode))
+		return nil
+	}
+
+	batchThreshold := 0
+	if batchMode == transforms.BatchBySize || batchMode == transforms.BatchByTimeOrSize {
+		thresholdVal, ok := parameters[BatchThreshold]
+		if !ok {
+			dynamic.Sdk.LoggingClient.Error("Could not find " + BatchThreshold)
+			return nil
+		}
+		var err error
+		batchThreshold, err = strconv.Atoi(thresholdVal)
+		if err != nil {
+			dynamic.Sdk.LoggingClient.Error(fmt.Sprintf("Could not parse '%s' to an int for '%s' parameter", thresholdVal, BatchThreshold), "error", err)
+			return nil
+		}
+	}
+
+	var timeInterval time.Duration
+	if batchMode == transforms.BatchByTime || batchMode == transforms.BatchByTimeOrSize {
+		intervalVal, ok := parameters[TimeInterval]
+		if !ok {
+			dynamic.Sdk.LoggingClient.Error("Could not find " + TimeInterval)
+			return nil
+		}
+		var err error
+		timeInterval, err = time.ParseDuration(intervalVal)
+		if err != nil {
+			dynamic.Sdk.LoggingClient.Error(fmt.Sprintf("Could not parse '%s' to a duration for '%s' parameter", intervalVal, TimeInterval), "error", err)
+			return nil
+		}
+	}
+
+	dynamic.Sdk.LoggingClient.Debug("Batch Parameters", Mode, mode, BatchThreshold, batchThreshold, TimeInterval, timeInterval.String())
+
+	batch := transforms.NewBatchConfig(dynamic.Sdk.AppContext(), batchMode, batchThreshold, timeInterval)
+	dynamic.Sdk.RegisterShutdownHandler(func() {
+		batch.Stop()
+		pending := batch.PendingCount()
+		flushed := batch.Flush()
+		if pending > 0 {
+			dynamic.Sdk.LoggingClient.Warn(fmt.Sprintf("Batch shutdown flushed %d pending item(s) (%d bytes) that were not forwarded to the pipeline", pending, len(flushed)))
+		}
+	})
+	return batch.Batch
+}
+
+// FilterByJSONPath stops the pipeline unless Expression resolves against the JSON payload and,
+// when the optional Match is set, the resolved value matches it (as a regular expression).
+// Expression is compiled at construction time so a typo in the pipeline configuration fails fast
+// at startup instead of on the first event processed.
+// This function is a configuration function and returns a function pointer.
+func (dynamic AppFunctionsSDKConfigurable) FilterByJSONPath(parameters map[string]string) appcontext.AppFunction {
+	expression, ok := parameters[Expression]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + Expression)
+		return nil
+	}
+
+	filter, err := transforms.NewJSONPathFilter(strings.TrimSpace(expression), parameters[Match])
+	if err != nil {
+		dynamic.Sdk.LoggingClient.Error("FilterByJSONPath configuration error", "error", err)
+		return nil
+	}
+
+	dynamic.Sdk.LoggingClient.Debug("FilterByJSONPath Parameters", Expression, expression, Match, parameters[Match])
+	return filter.FilterByJSONPath
+}
+
+// ExtractJSONPath replaces the pipeline payload with the JSON subtree resolved by Expression,
+// so later stages such as HTTPPost or MQTTSend transmit only the projection rather than the full
+// EdgeX Event envelope. Expression is compiled at construction time, failing fast on a bad path.
+// This function is a configuration function and returns a function pointer.
+func (dynamic AppFunctionsSDKConfigurable) ExtractJSONPath(parameters map[string]string) appcontext.AppFunction {
+	expression, ok := parameters[Expression]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + Expression)
+		return nil
+	}
+
+	extractor, err := transforms.NewJSONPathExtractor(strings.TrimSpace(expression))
+	if err != nil {
+		dynamic.Sdk.LoggingClient.Error("ExtractJSONPath configuration error", "error", err)
+		return nil
+	}
+
+	dynamic.Sdk.LoggingClient.Debug("ExtractJSONPath Parameters", Expression, expression)
+	return extractor.ExtractJSONPath
+}
+
+// FilterByXPath stops the pipeline unless Expression resolves a node against the XML payload and,
+// when the optional Match is set, the resolved node's text matches it (as a regular expression).
+// Expression is compiled at construction time, failing fast on a bad path.
+// This function is a configuration function and returns a function pointer.
+func (dynamic AppFunctionsSDKConfigurable) FilterByXPath(parameters map[string]string) appcontext.AppFunction {
+	expression, ok := parameters[Expression]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + Expression)
+		return nil
+	}
+
+	filter, err := transforms.NewXPathFilter(strings.TrimSpace(expression), parameters[Match])
+	if err != nil {
+		dynamic.Sdk.LoggingClient.Error("FilterByXPath configuration error", "error", err)
+		return nil
+	}
+
+	dynamic.Sdk.LoggingClient.Debug("FilterByXPath Parameters", Expression, expression, Match, parameters[Match])
+	return filter.FilterByXPath
+}
+
+// ExtractXPath replaces the pipeline payload with the XML of the node resolved by Expression, so
+// later stages such as HTTPPost or MQTTSend transmit only the projection rather than the full
+// EdgeX Event envelope. Expression is compiled at construction time, failing fast on a bad path.
+// This function is a configuration function and returns a function pointer.
+func (dynamic AppFunctionsSDKConfigurable) ExtractXPath(parameters map[string]string) appcontext.AppFunction {
+	expression, ok := parameters[Expression]
+	if !ok {
+		dynamic.Sdk.LoggingClient.Error("Could not find " + Expression)
+		return nil
+	}
+
+	extractor, err := transforms.NewXPathExtractor(strings.TrimSpace(expression))
+	if err != nil {
+		dynamic.Sdk.LoggingClient.Error("ExtractXPath configuration error", "error", err)
+		return nil
+	}
+
+	dynamic.Sdk.LoggingClient.Debug("ExtractXPath Parameters", Expression, expression)
+	return extractor.ExtractXPath
+}
+
 // SetOutputData sets the output data to that passed in from the previous function.
 // It will return an error and stop the pipeline if data passed in is not of type []byte, string or json.Mashaler
 // This function is a configuration function and returns a function pointer.
@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package externalmqtt implements the "external-mqtt" trigger, which allows a 3rd party to drive
+// an App Functions SDK pipeline over MQTT request/reply instead of the EdgeX message bus or
+// CoreData persistent events. This mirrors the query/request pattern used by EdgeX core-command
+// for 3rd-party interaction: a caller publishes a request to a well-known topic and receives the
+// pipeline's output on a response topic scoped to the request's correlation ID.
+package externalmqtt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	MQTT "github.com/eclipse/paho.mqtt.golang"
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+	"github.com/edgexfoundry/go-mod-core-contracts/clients/logger"
+)
+
+// TriggerType is the value of the Trigger.Type configuration setting that selects this trigger.
+const TriggerType = "external-mqtt"
+
+// Config holds the settings Trigger itself needs to subscribe to inbound requests. The broker
+// connection details (TLS cert/key, retain, autoreconnect, persist-on-error) and the response
+// topic prefix are not part of this Config: they belong to the MQTT client and PublishMQTTResponse
+// configuration built by the out-of-tree SDK bootstrap code that constructs the shared
+// *MQTT.Client passed to NewTrigger and the ResponseTopic passed to PublishMQTTResponse
+// respectively, so there is a single source of truth for each instead of it being duplicated here.
+type Config struct {
+	RequestTopic string
+	Qos          byte
+}
+
+// Trigger subscribes to Config.RequestTopic and feeds each inbound MQTT message into the pipeline
+// as the initial payload. Trigger does not publish the pipeline's final output itself; that is
+// left to the PublishMQTTResponse pipeline function so pipeline authors can choose when, and how
+// many times, to reply.
+type Trigger struct {
+	Logger           logger.LoggingClient
+	Config           Config
+	Client           MQTT.Client
+	ContextBuilder   func(payload []byte) *appcontext.Context
+	MessageProcessor func(ctx *appcontext.Context, payload []byte) error
+}
+
+// NewTrigger creates, initializes and returns a new instance of Trigger, ready to have Initialize
+// called on it. This is the constructor the "external-mqtt" case of the SDK's trigger-selection
+// switch should call, the same way the "messagebus" and "http" cases construct their own triggers.
+func NewTrigger(loggingClient logger.LoggingClient, config Config, client MQTT.Client, contextBuilder func(payload []byte) *appcontext.Context, messageProcessor func(ctx *appcontext.Context, payload []byte) error) *Trigger {
+	return &Trigger{
+		Logger:           loggingClient,
+		Config:           config,
+		Client:           client,
+		ContextBuilder:   contextBuilder,
+		MessageProcessor: messageProcessor,
+	}
+}
+
+// Initialize connects to the configured MQTT broker and subscribes to the request topic. The
+// returned error stops the SDK from starting if the broker cannot be reached.
+func (trigger *Trigger) Initialize(appWg *sync.WaitGroup, appCtx context.Context, _ <-chan interface{}) error {
+	if token := trigger.Client.Connect(); token.Wait() && token.Error() != nil {
+		return fmt.Errorf("unable to connect to external-mqtt broker: %w", token.Error())
+	}
+
+	token := trigger.Client.Subscribe(trigger.Config.RequestTopic, trigger.Config.Qos, trigger.messageHandler)
+	if token.Wait() && token.Error() != nil {
+		return fmt.Errorf("unable to subscribe to request topic '%s': %w", trigger.Config.RequestTopic, token.Error())
+	}
+
+	trigger.Logger.Info(fmt.Sprintf("external-mqtt trigger subscribed to request topic '%s'", trigger.Config.RequestTopic))
+
+	appWg.Add(1)
+	go func() {
+		defer appWg.Done()
+		<-appCtx.Done()
+		trigger.Client.Disconnect(250)
+		trigger.Logger.Info("external-mqtt trigger disconnected")
+	}()
+
+	return nil
+}
+
+// messageHandler is invoked by the MQTT client for each inbound request. It tags the resulting
+// edgeX context with a correlation ID derived from the message (or a generated one) so that a
+// downstream PublishMQTTResponse call can address the reply to the originating request.
+func (trigger *Trigger) messageHandler(_ MQTT.Client, message MQTT.Message) {
+	edgexcontext := trigger.ContextBuilder(message.Payload())
+	if edgexcontext.CorrelationID == "" {
+		edgexcontext.CorrelationID = uuid.New().String()
+	}
+
+	if err := trigger.MessageProcessor(edgexcontext, message.Payload()); err != nil {
+		trigger.Logger.Error(fmt.Sprintf("external-mqtt trigger failed to process message on topic '%s'", trigger.Config.RequestTopic), "error", err)
+	}
+}
@@ -0,0 +1,99 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package externalmqtt
+
+import (
+	"errors"
+	"testing"
+
+	MQTT "github.com/eclipse/paho.mqtt.golang"
+	"github.com/edgexfoundry/go-mod-bootstrap/bootstrap/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+)
+
+type fakeMessage struct {
+	payload []byte
+}
+
+func (m *fakeMessage) Duplicate() bool   { return false }
+func (m *fakeMessage) Qos() byte         { return 0 }
+func (m *fakeMessage) Retained() bool    { return false }
+func (m *fakeMessage) Topic() string     { return "edgex/pipeline/request/thermostat" }
+func (m *fakeMessage) MessageID() uint16 { return 1 }
+func (m *fakeMessage) Payload() []byte   { return m.payload }
+func (m *fakeMessage) Ack()              {}
+
+func newTestTrigger(processor func(ctx *appcontext.Context, payload []byte) error) *Trigger {
+	return NewTrigger(
+		logging.FactoryToStdout("externalmqtt-test"),
+		Config{RequestTopic: "edgex/pipeline/request/#"},
+		nil,
+		func(payload []byte) *appcontext.Context { return &appcontext.Context{} },
+		processor,
+	)
+}
+
+func TestMessageHandlerGeneratesCorrelationIDWhenMissing(t *testing.T) {
+	var gotCorrelationID string
+	trigger := newTestTrigger(func(ctx *appcontext.Context, payload []byte) error {
+		gotCorrelationID = ctx.CorrelationID
+		return nil
+	})
+
+	trigger.messageHandler(nil, &fakeMessage{payload: []byte("hello")})
+
+	assert.NotEmpty(t, gotCorrelationID)
+}
+
+func TestMessageHandlerPreservesCorrelationIDFromContextBuilder(t *testing.T) {
+	trigger := NewTrigger(
+		logging.FactoryToStdout("externalmqtt-test"),
+		Config{RequestTopic: "edgex/pipeline/request/#"},
+		nil,
+		func(payload []byte) *appcontext.Context { return &appcontext.Context{CorrelationID: "preset"} },
+		func(ctx *appcontext.Context, payload []byte) error { return nil },
+	)
+
+	var gotPayload []byte
+	trigger.MessageProcessor = func(ctx *appcontext.Context, payload []byte) error {
+		gotPayload = payload
+		assert.Equal(t, "preset", ctx.CorrelationID)
+		return nil
+	}
+
+	trigger.messageHandler(nil, &fakeMessage{payload: []byte("hello")})
+
+	assert.Equal(t, []byte("hello"), gotPayload)
+}
+
+func TestMessageHandlerLogsProcessorError(t *testing.T) {
+	processed := false
+	trigger := newTestTrigger(func(ctx *appcontext.Context, payload []byte) error {
+		processed = true
+		return errors.New("boom")
+	})
+
+	require.NotPanics(t, func() {
+		trigger.messageHandler(nil, &fakeMessage{payload: []byte("hello")})
+	})
+	assert.True(t, processed)
+}
+
+var _ MQTT.Message = (*fakeMessage)(nil)
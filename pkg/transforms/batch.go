@@ -0,0 +1,207 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+)
+
+// BatchMode controls what makes an in-progress batch ready to forward.
+type BatchMode string
+
+const (
+	BatchBySize       BatchMode = "BySize"
+	BatchByTime       BatchMode = "ByTime"
+	BatchByTimeOrSize BatchMode = "ByTimeOrSize"
+)
+
+// BatchConfig buffers the payloads received by successive pipeline invocations and only forwards
+// them to the next pipeline stage once BatchThreshold items have accumulated (BySize), a
+// TimeInterval tick has found the buffer non-empty (ByTime), or whichever comes first
+// (ByTimeOrSize). The ticker runs from construction, not from the oldest buffered item, and a
+// batch made ready by it is forwarded by the next call to Batch, not by the ticker itself.
+// This lets high-volume pipelines amortize the cost of an expensive downstream stage, such as
+// CompressWithGZIP or HTTPPost, across many inbound events instead of invoking it per event.
+type BatchConfig struct {
+	Mode           BatchMode
+	BatchThreshold int
+	TimeInterval   time.Duration
+
+	mutex      sync.Mutex
+	buffer     [][]byte
+	ready      bool
+	cancelFunc context.CancelFunc
+}
+
+// NewBatchConfig creates, initializes and returns a new instance of BatchConfig. When Mode is
+// ByTime or ByTimeOrSize, a background goroutine is started that marks the batch ready once
+// TimeInterval elapses; ctx cancels that goroutine, which callers must do on SDK shutdown via Stop.
+func NewBatchConfig(ctx context.Context, mode BatchMode, batchThreshold int, timeInterval time.Duration) *BatchConfig {
+	batch := &BatchConfig{
+		Mode:           mode,
+		BatchThreshold: batchThreshold,
+		TimeInterval:   timeInterval,
+	}
+
+	if mode == BatchByTime || mode == BatchByTimeOrSize {
+		timerCtx, cancel := context.WithCancel(ctx)
+		batch.cancelFunc = cancel
+		go batch.runTimer(timerCtx)
+	}
+
+	return batch
+}
+
+// runTimer marks the batch ready on every tick that finds buffered data. It exits when ctx is
+// cancelled, which happens when Stop is called on SDK shutdown.
+func (batch *BatchConfig) runTimer(ctx context.Context) {
+	ticker := time.NewTicker(batch.TimeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			batch.mutex.Lock()
+			if len(batch.buffer) > 0 {
+				batch.ready = true
+			}
+			batch.mutex.Unlock()
+		}
+	}
+}
+
+// Batch appends the data received from the previous function (or the triggering Event if there is
+// none) to the in-memory batch. It returns false, halting the pipeline, until the configured
+// threshold or timer makes the batch ready, at which point it returns true along with the
+// accumulated batch and resets the buffer for the next round.
+// This function is a pipeline function and returns a function pointer.
+func (batch *BatchConfig) Batch(edgexcontext *appcontext.Context, params ...interface{}) (bool, interface{}) {
+	if len(params) < 1 {
+		return false, nil
+	}
+
+	data, err := batch.toBytes(params[0])
+	if err != nil {
+		return false, err
+	}
+
+	batch.mutex.Lock()
+	defer batch.mutex.Unlock()
+
+	batch.buffer = append(batch.buffer, data)
+
+	full := (batch.Mode == BatchBySize || batch.Mode == BatchByTimeOrSize) && len(batch.buffer) >= batch.BatchThreshold
+	if !full && !batch.ready {
+		edgexcontext.LoggingClient.Debug(fmt.Sprintf("Batch buffered %d of %d", len(batch.buffer), batch.BatchThreshold))
+		return false, nil
+	}
+
+	return true, batch.flushLocked()
+}
+
+// PendingCount returns the number of items currently buffered and not yet flushed.
+func (batch *BatchConfig) PendingCount() int {
+	batch.mutex.Lock()
+	defer batch.mutex.Unlock()
+	return len(batch.buffer)
+}
+
+// Flush returns any buffered data and clears the batch. It is safe to call concurrently with
+// Batch and is intended to be invoked once, alongside Stop, during SDK shutdown. The caller is
+// responsible for doing something with the returned bytes: Flush only empties the buffer, it does
+// not forward the data anywhere, so a caller that ignores the return value drops the partially
+// filled batch on the floor.
+func (batch *BatchConfig) Flush() []byte {
+	batch.mutex.Lock()
+	defer batch.mutex.Unlock()
+	if len(batch.buffer) == 0 {
+		return nil
+	}
+	return batch.flushLocked()
+}
+
+// Stop cancels the background timer goroutine started for ByTime/ByTimeOrSize modes. It is a
+// no-op for BySize batches and safe to call more than once.
+func (batch *BatchConfig) Stop() {
+	if batch.cancelFunc != nil {
+		batch.cancelFunc()
+	}
+}
+
+func (batch *BatchConfig) flushLocked() []byte {
+	pending := batch.buffer
+	batch.buffer = nil
+	batch.ready = false
+	return marshalBatch(pending)
+}
+
+// toBytes normalizes the data handed between pipeline functions, mirroring the types accepted by
+// CompressWithGZIP and HTTPPost, into raw bytes for buffering.
+func (batch *BatchConfig) toBytes(data interface{}) ([]byte, error) {
+	switch typed := data.(type) {
+	case []byte:
+		return typed, nil
+	case string:
+		return []byte(typed), nil
+	case json.Marshaler:
+		return typed.MarshalJSON()
+	default:
+		return json.Marshal(typed)
+	}
+}
+
+// marshalBatch emits the buffered items as a single JSON array when every item is JSON, or as a
+// length-prefixed concatenation of the raw bytes otherwise.
+func marshalBatch(items [][]byte) []byte {
+	for _, item := range items {
+		if !json.Valid(item) {
+			return marshalBatchRaw(items)
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		out.Write(item)
+	}
+	out.WriteByte(']')
+	return out.Bytes()
+}
+
+func marshalBatchRaw(items [][]byte) []byte {
+	var out bytes.Buffer
+	for _, item := range items {
+		length := make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(item)))
+		out.Write(length)
+		out.Write(item)
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,87 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchBySizeThreshold(t *testing.T) {
+	batch := NewBatchConfig(context.Background(), BatchBySize, 2, 0)
+
+	continuePipeline, result := batch.Batch(newTestContext(), []byte(`{"a":1}`))
+	assert.False(t, continuePipeline)
+	assert.Nil(t, result)
+
+	continuePipeline, result = batch.Batch(newTestContext(), []byte(`{"b":2}`))
+	require.True(t, continuePipeline)
+
+	var decoded []map[string]int
+	require.NoError(t, json.Unmarshal(result.([]byte), &decoded))
+	assert.Equal(t, []map[string]int{{"a": 1}, {"b": 2}}, decoded)
+}
+
+func TestBatchByTimeFlushesOnTicker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	batch := NewBatchConfig(ctx, BatchByTime, 0, 10*time.Millisecond)
+
+	continuePipeline, _ := batch.Batch(newTestContext(), []byte(`{"a":1}`))
+	assert.False(t, continuePipeline)
+
+	require.Eventually(t, func() bool {
+		continuePipeline, _ = batch.Batch(newTestContext(), []byte(`{"b":2}`))
+		return continuePipeline
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestBatchNoDataReturnsFalse(t *testing.T) {
+	batch := NewBatchConfig(context.Background(), BatchBySize, 2, 0)
+
+	continuePipeline, result := batch.Batch(newTestContext())
+
+	assert.False(t, continuePipeline)
+	assert.Nil(t, result)
+}
+
+func TestPendingCountAndFlush(t *testing.T) {
+	batch := NewBatchConfig(context.Background(), BatchBySize, 10, 0)
+
+	batch.Batch(newTestContext(), []byte(`{"a":1}`))
+	assert.Equal(t, 1, batch.PendingCount())
+
+	flushed := batch.Flush()
+	assert.NotNil(t, flushed)
+	assert.Equal(t, 0, batch.PendingCount())
+
+	assert.Nil(t, batch.Flush())
+}
+
+func TestStopCancelsTimerGoroutine(t *testing.T) {
+	batch := NewBatchConfig(context.Background(), BatchByTime, 0, time.Millisecond)
+	assert.NotPanics(t, func() {
+		batch.Stop()
+		batch.Stop()
+	})
+}
@@ -0,0 +1,124 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/clients/command"
+	"github.com/edgexfoundry/go-mod-core-contracts/models"
+
+	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+)
+
+// CoreCommand issues an EdgeX device command through the CommandClient and passes the command's
+// response to the next function in the pipeline so downstream functions can act on it.
+type CoreCommand struct {
+	DeviceName    string
+	CommandName   string
+	Method        string
+	BodyTemplate  string
+	CommandClient command.CommandClient
+}
+
+// IssueCoreCommand issues the configured command against CommandName on DeviceName via GET or PUT
+// and returns the command's response as the data passed to the next function in the pipeline.
+// It will return an error and stop the pipeline if the command client is not configured, the
+// Method is unsupported or the command request fails.
+// This function is a pipeline function and returns a function pointer.
+func (command CoreCommand) IssueCoreCommand(edgexcontext *appcontext.Context, params ...interface{}) (bool, interface{}) {
+	if command.CommandClient == nil {
+		return false, fmt.Errorf("IssueCoreCommand: command client was not initialized")
+	}
+
+	switch strings.ToUpper(command.Method) {
+	case "GET":
+		response, err := command.CommandClient.Get(context.Background(), command.DeviceName, command.CommandName)
+		if err != nil {
+			return false, fmt.Errorf("IssueCoreCommand: GET request to device '%s' command '%s' failed: %w", command.DeviceName, command.CommandName, err)
+		}
+		edgexcontext.LoggingClient.Debug("IssueCoreCommand GET response", "device", command.DeviceName, "command", command.CommandName)
+		return true, response
+
+	case "PUT":
+		body, err := command.buildBody(edgexcontext, params)
+		if err != nil {
+			return false, err
+		}
+
+		response, err := command.CommandClient.Put(context.Background(), command.DeviceName, command.CommandName, body)
+		if err != nil {
+			return false, fmt.Errorf("IssueCoreCommand: PUT request to device '%s' command '%s' failed: %w", command.DeviceName, command.CommandName, err)
+		}
+		edgexcontext.LoggingClient.Debug("IssueCoreCommand PUT response", "device", command.DeviceName, "command", command.CommandName)
+		return true, response
+
+	default:
+		return false, fmt.Errorf("IssueCoreCommand: unsupported method '%s', must be GET or PUT", command.Method)
+	}
+}
+
+// commandBodyTemplateData is the friendly shape BodyTemplate is rendered against, so templates
+// can reference `{{.ReadingName}}` and `{{.Value}}` instead of having to know the field names of
+// the underlying models.Reading.
+type commandBodyTemplateData struct {
+	DeviceName  string
+	ReadingName string
+	Value       string
+}
+
+// buildBody renders BodyTemplate, substituting fields of the Event/Reading that triggered the
+// pipeline (e.g. `{{.ReadingName}}`), for use as the PUT command's request body. An empty
+// BodyTemplate results in an empty body.
+func (command CoreCommand) buildBody(edgexcontext *appcontext.Context, params []interface{}) (string, error) {
+	if command.BodyTemplate == "" {
+		return "", nil
+	}
+
+	event := edgexcontext.Event
+	if len(params) > 0 {
+		if asEvent, isEvent := params[0].(models.Event); isEvent {
+			event = asEvent
+		}
+	}
+	if len(event.Readings) == 0 {
+		return "", fmt.Errorf("IssueCoreCommand: no reading available to build command body from")
+	}
+
+	tmpl, err := template.New("body").Parse(command.BodyTemplate)
+	if err != nil {
+		return "", fmt.Errorf("IssueCoreCommand: invalid BodyTemplate: %w", err)
+	}
+
+	reading := event.Readings[0]
+	data := commandBodyTemplateData{
+		DeviceName:  command.DeviceName,
+		ReadingName: reading.Name,
+		Value:       reading.Value,
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("IssueCoreCommand: failed to render BodyTemplate: %w", err)
+	}
+
+	return rendered.String(), nil
+}
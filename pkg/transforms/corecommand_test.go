@@ -0,0 +1,119 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/bootstrap/logging"
+	"github.com/edgexfoundry/go-mod-core-contracts/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+)
+
+type mockCommandClient struct {
+	getResponse string
+	getErr      error
+	putResponse string
+	putErr      error
+	putBody     string
+}
+
+func (client *mockCommandClient) Get(_ context.Context, _ string, _ string) (string, error) {
+	return client.getResponse, client.getErr
+}
+
+func (client *mockCommandClient) Put(_ context.Context, _ string, _ string, body string) (string, error) {
+	client.putBody = body
+	return client.putResponse, client.putErr
+}
+
+func newTestContext() *appcontext.Context {
+	return &appcontext.Context{
+		LoggingClient: logging.FactoryToStdout("corecommand-test"),
+	}
+}
+
+func TestIssueCoreCommandNoClient(t *testing.T) {
+	command := CoreCommand{DeviceName: "device1", CommandName: "cmd1", Method: "GET"}
+
+	continuePipeline, result := command.IssueCoreCommand(newTestContext())
+
+	assert.False(t, continuePipeline)
+	require.Error(t, result.(error))
+}
+
+func TestIssueCoreCommandGet(t *testing.T) {
+	client := &mockCommandClient{getResponse: "42"}
+	command := CoreCommand{DeviceName: "device1", CommandName: "cmd1", Method: "get", CommandClient: client}
+
+	continuePipeline, result := command.IssueCoreCommand(newTestContext())
+
+	require.True(t, continuePipeline)
+	assert.Equal(t, "42", result)
+}
+
+func TestIssueCoreCommandPutRendersBodyTemplate(t *testing.T) {
+	client := &mockCommandClient{putResponse: "ok"}
+	command := CoreCommand{
+		DeviceName:    "device1",
+		CommandName:   "cmd1",
+		Method:        "PUT",
+		BodyTemplate:  "{{.ReadingName}}={{.Value}}",
+		CommandClient: client,
+	}
+
+	edgexcontext := newTestContext()
+	edgexcontext.Event = models.Event{
+		Readings: []models.Reading{{Name: "temperature", Value: "72"}},
+	}
+
+	continuePipeline, result := command.IssueCoreCommand(edgexcontext)
+
+	require.True(t, continuePipeline)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, "temperature=72", client.putBody)
+}
+
+func TestIssueCoreCommandUnsupportedMethod(t *testing.T) {
+	command := CoreCommand{DeviceName: "device1", CommandName: "cmd1", Method: "DELETE", CommandClient: &mockCommandClient{}}
+
+	continuePipeline, result := command.IssueCoreCommand(newTestContext())
+
+	assert.False(t, continuePipeline)
+	require.Error(t, result.(error))
+}
+
+func TestBuildBodyNoReadingAvailable(t *testing.T) {
+	command := CoreCommand{BodyTemplate: "{{.ReadingName}}"}
+
+	_, err := command.buildBody(newTestContext(), nil)
+
+	require.Error(t, err)
+}
+
+func TestBuildBodyEmptyTemplate(t *testing.T) {
+	command := CoreCommand{}
+
+	body, err := command.buildBody(newTestContext(), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "", body)
+}
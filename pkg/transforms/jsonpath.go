@@ -0,0 +1,172 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/oliveagle/jsonpath"
+
+	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+)
+
+// JSONPathFilter evaluates a compiled JSONPath expression against the pipeline's payload and
+// drops the event if the expression does not resolve, or does not satisfy Match when one is set.
+type JSONPathFilter struct {
+	Expression string
+	Match      string
+	compiled   *jsonpath.Compiled
+	matcher    *regexp.Regexp
+}
+
+// NewJSONPathFilter compiles expression and, if match is non-empty, compiles it as a regular
+// expression, returning an error immediately if either fails to parse so pipeline construction
+// fails fast rather than surfacing the error on the first event processed.
+func NewJSONPathFilter(expression string, match string) (*JSONPathFilter, error) {
+	compiled, err := jsonpath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath expression '%s': %w", expression, err)
+	}
+
+	filter := &JSONPathFilter{
+		Expression: expression,
+		Match:      match,
+		compiled:   compiled,
+	}
+
+	if match != "" {
+		filter.matcher, err = regexp.Compile(match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Match regular expression '%s': %w", match, err)
+		}
+	}
+
+	return filter, nil
+}
+
+// FilterByJSONPath stops the pipeline unless Expression resolves against the data received from
+// the previous function (or the triggering Event if there is none) and, when Match is set, the
+// resolved value matches it as a regular expression.
+// This function is a pipeline function and returns a function pointer.
+func (filter *JSONPathFilter) FilterByJSONPath(edgexcontext *appcontext.Context, params ...interface{}) (bool, interface{}) {
+	if len(params) < 1 {
+		return false, nil
+	}
+
+	document, err := toJSONDocument(params[0])
+	if err != nil {
+		return false, err
+	}
+
+	value, err := filter.compiled.Lookup(document)
+	if err != nil {
+		edgexcontext.LoggingClient.Debug(fmt.Sprintf("FilterByJSONPath: expression '%s' did not resolve", filter.Expression), "error", err)
+		return false, nil
+	}
+
+	if filter.matcher != nil {
+		if !filter.matcher.MatchString(matchText(value)) {
+			return false, nil
+		}
+	}
+
+	return true, params[0]
+}
+
+// matchText stringifies a value resolved from JSON for matching against Match. json.Unmarshal
+// decodes JSON numbers as float64, and fmt's default formatting switches large round numbers to
+// scientific notation (e.g. 100000000 becomes "1e+08"), which would silently break a Match regular
+// expression expecting plain digits; format float64 explicitly to avoid that.
+func matchText(value interface{}) string {
+	if number, isFloat := value.(float64); isFloat {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// JSONPathExtractor replaces the pipeline's payload with the subtree resolved by a compiled
+// JSONPath expression.
+type JSONPathExtractor struct {
+	Expression string
+	compiled   *jsonpath.Compiled
+}
+
+// NewJSONPathExtractor compiles expression, returning an error immediately if it fails to parse.
+func NewJSONPathExtractor(expression string) (*JSONPathExtractor, error) {
+	compiled, err := jsonpath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath expression '%s': %w", expression, err)
+	}
+
+	return &JSONPathExtractor{Expression: expression, compiled: compiled}, nil
+}
+
+// ExtractJSONPath replaces the pipeline's payload with the JSON-encoded subtree resolved by
+// Expression against the data received from the previous function (or the triggering Event if
+// there is none).
+// This function is a pipeline function and returns a function pointer.
+func (extractor *JSONPathExtractor) ExtractJSONPath(edgexcontext *appcontext.Context, params ...interface{}) (bool, interface{}) {
+	if len(params) < 1 {
+		return false, nil
+	}
+
+	document, err := toJSONDocument(params[0])
+	if err != nil {
+		return false, err
+	}
+
+	value, err := extractor.compiled.Lookup(document)
+	if err != nil {
+		return false, fmt.Errorf("ExtractJSONPath: expression '%s' did not resolve: %w", extractor.Expression, err)
+	}
+
+	extracted, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("ExtractJSONPath: failed to marshal extracted value: %w", err)
+	}
+
+	return true, extracted
+}
+
+// toJSONDocument normalizes []byte/string JSON payloads and already-unmarshalled EdgeX models
+// (or any other json.Marshaler) into the generic interface{} shape jsonpath.Compiled expects.
+func toJSONDocument(data interface{}) (interface{}, error) {
+	var raw []byte
+	var err error
+
+	switch typed := data.(type) {
+	case []byte:
+		raw = typed
+	case string:
+		raw = []byte(typed)
+	default:
+		raw, err = json.Marshal(typed)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal payload to JSON: %w", err)
+		}
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal payload as JSON: %w", err)
+	}
+
+	return document, nil
+}
@@ -0,0 +1,109 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJSONPathFilterInvalidExpression(t *testing.T) {
+	_, err := NewJSONPathFilter("$[", "")
+	require.Error(t, err)
+}
+
+func TestNewJSONPathFilterInvalidMatch(t *testing.T) {
+	_, err := NewJSONPathFilter("$.reading", "[")
+	require.Error(t, err)
+}
+
+func TestFilterByJSONPathMatch(t *testing.T) {
+	filter, err := NewJSONPathFilter("$.reading", "72")
+	require.NoError(t, err)
+
+	continuePipeline, result := filter.FilterByJSONPath(newTestContext(), []byte(`{"reading":"72"}`))
+
+	require.True(t, continuePipeline)
+	assert.Equal(t, []byte(`{"reading":"72"}`), result)
+}
+
+func TestFilterByJSONPathMatchLargeRoundNumber(t *testing.T) {
+	filter, err := NewJSONPathFilter("$.reading", "100000000")
+	require.NoError(t, err)
+
+	continuePipeline, _ := filter.FilterByJSONPath(newTestContext(), []byte(`{"reading":100000000}`))
+
+	assert.True(t, continuePipeline)
+}
+
+func TestFilterByJSONPathNoMatch(t *testing.T) {
+	filter, err := NewJSONPathFilter("$.reading", "72")
+	require.NoError(t, err)
+
+	continuePipeline, _ := filter.FilterByJSONPath(newTestContext(), []byte(`{"reading":"99"}`))
+
+	assert.False(t, continuePipeline)
+}
+
+func TestFilterByJSONPathExpressionDoesNotResolve(t *testing.T) {
+	filter, err := NewJSONPathFilter("$.missing", "")
+	require.NoError(t, err)
+
+	continuePipeline, result := filter.FilterByJSONPath(newTestContext(), []byte(`{"reading":"72"}`))
+
+	assert.False(t, continuePipeline)
+	assert.Nil(t, result)
+}
+
+func TestFilterByJSONPathNoData(t *testing.T) {
+	filter, err := NewJSONPathFilter("$.reading", "")
+	require.NoError(t, err)
+
+	continuePipeline, _ := filter.FilterByJSONPath(newTestContext())
+
+	assert.False(t, continuePipeline)
+}
+
+func TestExtractJSONPath(t *testing.T) {
+	extractor, err := NewJSONPathExtractor("$.reading")
+	require.NoError(t, err)
+
+	continuePipeline, result := extractor.ExtractJSONPath(newTestContext(), []byte(`{"reading":"72"}`))
+
+	require.True(t, continuePipeline)
+	assert.Equal(t, []byte(`"72"`), result)
+}
+
+func TestExtractJSONPathDoesNotResolve(t *testing.T) {
+	extractor, err := NewJSONPathExtractor("$.missing")
+	require.NoError(t, err)
+
+	_, result := extractor.ExtractJSONPath(newTestContext(), []byte(`{"reading":"72"}`))
+
+	require.Error(t, result.(error))
+}
+
+func TestExtractJSONPathInvalidJSON(t *testing.T) {
+	extractor, err := NewJSONPathExtractor("$.reading")
+	require.NoError(t, err)
+
+	_, result := extractor.ExtractJSONPath(newTestContext(), []byte(`not json`))
+
+	require.Error(t, result.(error))
+}
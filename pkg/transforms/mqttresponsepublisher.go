@@ -0,0 +1,71 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+	"github.com/edgexfoundry/go-mod-core-contracts/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/models"
+)
+
+// MQTTResponsePublisher publishes the payload produced by a pipeline back to an MQTT broker on a
+// topic derived from a configured prefix and the correlation ID of the Event that triggered the
+// pipeline. It reuses the same broker connection parameters as MQTTSender so that request/reply
+// pipelines driven by the external-mqtt trigger can answer on a per-request topic.
+type MQTTResponsePublisher struct {
+	sender              *MQTTSender
+	responseTopicPrefix string
+	mutex               sync.Mutex
+}
+
+// NewMQTTResponsePublisher creates, initializes and returns a new instance of MQTTResponsePublisher.
+func NewMQTTResponsePublisher(loggingClient logger.LoggingClient, addr models.Addressable, responseTopicPrefix string, keyCertPair *KeyCertPair, mqttConfig MqttConfig, persistOnError bool) *MQTTResponsePublisher {
+	return &MQTTResponsePublisher{
+		sender:              NewMQTTSender(loggingClient, addr, keyCertPair, mqttConfig, persistOnError),
+		responseTopicPrefix: responseTopicPrefix,
+	}
+}
+
+// PublishMQTTResponse publishes the data received from the previous function to the response
+// topic formed by appending the triggering request's correlation ID to the configured response
+// topic prefix, e.g. `edgex/pipeline/response/<correlation-id>`. If no previous function exists,
+// then the event that triggered the pipeline will be used.
+//
+// The underlying MQTTSender is shared across every invocation of the pipeline, so the mutex
+// serializes the read-modify-send-restore sequence below; without it, concurrent in-flight
+// requests could race on sender.addr and deliver one caller's response to another caller's topic.
+// This function is a pipeline function and returns a function pointer.
+func (publisher *MQTTResponsePublisher) PublishMQTTResponse(edgexcontext *appcontext.Context, params ...interface{}) (bool, interface{}) {
+	if len(params) < 1 {
+		return false, nil
+	}
+
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	originalAddr := publisher.sender.addr
+	defer func() { publisher.sender.addr = originalAddr }()
+
+	responseAddr := originalAddr
+	responseAddr.Topic = fmt.Sprintf("%s/%s", publisher.responseTopicPrefix, edgexcontext.CorrelationID)
+	publisher.sender.addr = responseAddr
+
+	return publisher.sender.MQTTSend(edgexcontext, params...)
+}
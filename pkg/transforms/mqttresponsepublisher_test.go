@@ -0,0 +1,58 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/bootstrap/logging"
+	"github.com/edgexfoundry/go-mod-core-contracts/models"
+
+	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+)
+
+// TestPublishMQTTResponseConcurrentCallsDoNotRace exercises PublishMQTTResponse from many
+// goroutines at once, as would happen with overlapping in-flight external-mqtt requests. Run with
+// `go test -race` it catches a regression back to mutating the shared sender.addr without locking.
+// Every call targets an unreachable broker and is expected to fail; only the absence of a data
+// race and of a panic is asserted.
+func TestPublishMQTTResponseConcurrentCallsDoNotRace(t *testing.T) {
+	addr := models.Addressable{Address: "localhost", Port: 1, Protocol: "tcp", Publisher: "test"}
+	mqttConfig := NewMqttConfig()
+
+	publisher := NewMQTTResponsePublisher(
+		logging.FactoryToStdout("mqttresponsepublisher-test"),
+		addr,
+		"edgex/pipeline/response",
+		nil,
+		mqttConfig,
+		false,
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			edgexcontext := &appcontext.Context{CorrelationID: fmt.Sprintf("corr-%d", id)}
+			publisher.PublishMQTTResponse(edgexcontext, []byte("payload"))
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,121 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/clients/notifications"
+	"github.com/edgexfoundry/go-mod-core-contracts/models"
+
+	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+)
+
+// Notification sends an EdgeX Notification, built from the pipeline's payload, to the EdgeX
+// notifications service via the NotificationsClient.
+type Notification struct {
+	Sender              string
+	Category            string
+	Severity            string
+	ContentType         string
+	Labels              []string
+	BodyTemplate        string
+	PersistOnError      bool
+	NotificationsClient notifications.NotificationsClient
+}
+
+// SendNotification renders BodyTemplate against the data received from the previous function (or
+// the triggering Event if there is none) and sends it as the notification's content. If the send
+// fails, the error is returned and stops the pipeline; when PersistOnError is true, the received
+// data is also stored in the context's RetryData so the SDK's store-and-forward subsystem will
+// retry it.
+// This function is a pipeline function and returns a function pointer.
+func (notification Notification) SendNotification(edgexcontext *appcontext.Context, params ...interface{}) (bool, interface{}) {
+	if len(params) < 1 {
+		return false, nil
+	}
+
+	if notification.NotificationsClient == nil {
+		return false, fmt.Errorf("SendNotification: notifications client was not initialized")
+	}
+
+	content, err := notification.renderContent(params[0])
+	if err != nil {
+		return false, err
+	}
+
+	model := models.Notification{
+		Slug:        fmt.Sprintf("%s-%s", notification.Sender, uuid.New().String()),
+		Sender:      notification.Sender,
+		Category:    models.NotificationsCategory(notification.Category),
+		Severity:    models.NotificationsSeverity(notification.Severity),
+		Content:     content,
+		ContentType: notification.ContentType,
+		Labels:      notification.Labels,
+		Status:      models.NotificationsStatus(models.New),
+		Created:     time.Now().UnixNano() / int64(time.Millisecond),
+	}
+
+	err = notification.NotificationsClient.SendNotification(context.Background(), model)
+	if err != nil {
+		if notification.PersistOnError {
+			edgexcontext.RetryData = params[0]
+		}
+		return false, fmt.Errorf("SendNotification: failed to send notification: %w", err)
+	}
+
+	return true, content
+}
+
+// renderContent turns the pipeline's current data into the notification's content, applying
+// BodyTemplate (if configured) with `{{.}}` referring to the data itself.
+func (notification Notification) renderContent(data interface{}) (string, error) {
+	if notification.BodyTemplate == "" {
+		switch typed := data.(type) {
+		case []byte:
+			return string(typed), nil
+		case string:
+			return typed, nil
+		default:
+			return fmt.Sprintf("%v", typed), nil
+		}
+	}
+
+	tmpl, err := template.New("notification").Parse(notification.BodyTemplate)
+	if err != nil {
+		return "", fmt.Errorf("SendNotification: invalid BodyTemplate: %w", err)
+	}
+
+	// text/template prints a []byte as its decimal-array Go representation rather than the text it
+	// holds, so normalize it the same way the no-template branch above does before rendering.
+	if raw, isBytes := data.([]byte); isBytes {
+		data = string(raw)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("SendNotification: failed to render BodyTemplate: %w", err)
+	}
+
+	return rendered.String(), nil
+}
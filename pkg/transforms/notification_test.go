@@ -0,0 +1,91 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockNotificationsClient struct {
+	sendErr error
+	sent    models.Notification
+}
+
+func (client *mockNotificationsClient) SendNotification(_ context.Context, notification models.Notification) error {
+	client.sent = notification
+	return client.sendErr
+}
+
+func TestRenderContentNoTemplateBytes(t *testing.T) {
+	notification := Notification{}
+
+	content, err := notification.renderContent([]byte("hello"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello", content)
+}
+
+func TestRenderContentTemplateBytesNotGarbled(t *testing.T) {
+	notification := Notification{BodyTemplate: "{{.}}"}
+
+	content, err := notification.renderContent([]byte("hello"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello", content)
+}
+
+func TestRenderContentTemplateStruct(t *testing.T) {
+	notification := Notification{BodyTemplate: "value={{.Value}}"}
+
+	content, err := notification.renderContent(struct{ Value string }{Value: "42"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "value=42", content)
+}
+
+func TestSendNotificationNoClient(t *testing.T) {
+	notification := Notification{}
+
+	continuePipeline, result := notification.SendNotification(newTestContext(), []byte("hello"))
+
+	assert.False(t, continuePipeline)
+	require.Error(t, result.(error))
+}
+
+func TestSendNotificationPersistsOnErrorWhenConfigured(t *testing.T) {
+	client := &mockNotificationsClient{sendErr: assert.AnError}
+	notification := Notification{
+		Sender:              "app",
+		Category:            "SW_HEALTH",
+		Severity:            "CRITICAL",
+		ContentType:         "text/plain",
+		PersistOnError:      true,
+		NotificationsClient: client,
+	}
+
+	edgexcontext := newTestContext()
+	continuePipeline, result := notification.SendNotification(edgexcontext, []byte("hello"))
+
+	assert.False(t, continuePipeline)
+	require.Error(t, result.(error))
+	assert.Equal(t, []byte("hello"), edgexcontext.RetryData)
+}
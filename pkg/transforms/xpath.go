@@ -0,0 +1,149 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+
+	"github.com/edgexfoundry/app-functions-sdk-go/appcontext"
+)
+
+// XPathFilter evaluates a compiled XPath expression against the pipeline's XML payload and drops
+// the event if the expression does not resolve to a node, or does not satisfy Match when one is
+// set.
+type XPathFilter struct {
+	Expression string
+	Match      string
+	compiled   *xpath.Expr
+	matcher    *regexp.Regexp
+}
+
+// NewXPathFilter compiles expression and, if match is non-empty, compiles it as a regular
+// expression, returning an error immediately if either fails to parse.
+func NewXPathFilter(expression string, match string) (*XPathFilter, error) {
+	compiled, err := xpath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XPath expression '%s': %w", expression, err)
+	}
+
+	filter := &XPathFilter{
+		Expression: expression,
+		Match:      match,
+		compiled:   compiled,
+	}
+
+	if match != "" {
+		filter.matcher, err = regexp.Compile(match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Match regular expression '%s': %w", match, err)
+		}
+	}
+
+	return filter, nil
+}
+
+// FilterByXPath stops the pipeline unless Expression resolves a node against the XML []byte or
+// string received from the previous function and, when Match is set, the resolved node's text
+// matches it. Any other payload type, including an EdgeX Event, is not converted to XML and
+// returns an error.
+// This function is a pipeline function and returns a function pointer.
+func (filter *XPathFilter) FilterByXPath(edgexcontext *appcontext.Context, params ...interface{}) (bool, interface{}) {
+	if len(params) < 1 {
+		return false, nil
+	}
+
+	doc, err := toXMLDocument(params[0])
+	if err != nil {
+		return false, err
+	}
+
+	node := xmlquery.QuerySelector(doc, filter.compiled)
+	if node == nil {
+		return false, nil
+	}
+
+	if filter.matcher != nil && !filter.matcher.MatchString(node.InnerText()) {
+		return false, nil
+	}
+
+	return true, params[0]
+}
+
+// XPathExtractor replaces the pipeline's XML payload with the node resolved by a compiled XPath
+// expression.
+type XPathExtractor struct {
+	Expression string
+	compiled   *xpath.Expr
+}
+
+// NewXPathExtractor compiles expression, returning an error immediately if it fails to parse.
+func NewXPathExtractor(expression string) (*XPathExtractor, error) {
+	compiled, err := xpath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XPath expression '%s': %w", expression, err)
+	}
+
+	return &XPathExtractor{Expression: expression, compiled: compiled}, nil
+}
+
+// ExtractXPath replaces the pipeline's payload with the XML text of the node resolved by
+// Expression against the XML []byte or string received from the previous function. Any other
+// payload type, including an EdgeX Event, is not converted to XML and returns an error.
+// This function is a pipeline function and returns a function pointer.
+func (extractor *XPathExtractor) ExtractXPath(edgexcontext *appcontext.Context, params ...interface{}) (bool, interface{}) {
+	if len(params) < 1 {
+		return false, nil
+	}
+
+	doc, err := toXMLDocument(params[0])
+	if err != nil {
+		return false, err
+	}
+
+	node := xmlquery.QuerySelector(doc, extractor.compiled)
+	if node == nil {
+		return false, fmt.Errorf("ExtractXPath: expression '%s' did not resolve to a node", extractor.Expression)
+	}
+
+	return true, []byte(node.OutputXML(true))
+}
+
+// toXMLDocument normalizes []byte/string XML payloads into a parsed xmlquery document.
+func toXMLDocument(data interface{}) (*xmlquery.Node, error) {
+	var text string
+
+	switch typed := data.(type) {
+	case []byte:
+		text = string(typed)
+	case string:
+		text = typed
+	default:
+		return nil, fmt.Errorf("unsupported payload type %T for XPath; expected XML []byte or string, try TransformToXML first", typed)
+	}
+
+	doc, err := xmlquery.Parse(strings.NewReader(text))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse payload as XML: %w", err)
+	}
+
+	return doc, nil
+}
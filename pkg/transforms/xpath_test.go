@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2020 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package transforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testXML = `<reading><name>temperature</name><value>72</value></reading>`
+
+func TestNewXPathFilterInvalidExpression(t *testing.T) {
+	_, err := NewXPathFilter("///", "")
+	require.Error(t, err)
+}
+
+func TestNewXPathFilterInvalidMatch(t *testing.T) {
+	_, err := NewXPathFilter("/reading/value", "[")
+	require.Error(t, err)
+}
+
+func TestFilterByXPathMatch(t *testing.T) {
+	filter, err := NewXPathFilter("/reading/value", "72")
+	require.NoError(t, err)
+
+	continuePipeline, result := filter.FilterByXPath(newTestContext(), []byte(testXML))
+
+	require.True(t, continuePipeline)
+	assert.Equal(t, []byte(testXML), result)
+}
+
+func TestFilterByXPathNoMatch(t *testing.T) {
+	filter, err := NewXPathFilter("/reading/value", "99")
+	require.NoError(t, err)
+
+	continuePipeline, _ := filter.FilterByXPath(newTestContext(), []byte(testXML))
+
+	assert.False(t, continuePipeline)
+}
+
+func TestFilterByXPathNodeNotFound(t *testing.T) {
+	filter, err := NewXPathFilter("/reading/missing", "")
+	require.NoError(t, err)
+
+	continuePipeline, _ := filter.FilterByXPath(newTestContext(), []byte(testXML))
+
+	assert.False(t, continuePipeline)
+}
+
+func TestFilterByXPathUnsupportedPayloadType(t *testing.T) {
+	filter, err := NewXPathFilter("/reading/value", "")
+	require.NoError(t, err)
+
+	_, result := filter.FilterByXPath(newTestContext(), 42)
+
+	require.Error(t, result.(error))
+}
+
+func TestExtractXPath(t *testing.T) {
+	extractor, err := NewXPathExtractor("/reading/value")
+	require.NoError(t, err)
+
+	continuePipeline, result := extractor.ExtractXPath(newTestContext(), []byte(testXML))
+
+	require.True(t, continuePipeline)
+	assert.Contains(t, string(result.([]byte)), "72")
+}
+
+func TestExtractXPathNodeNotFound(t *testing.T) {
+	extractor, err := NewXPathExtractor("/reading/missing")
+	require.NoError(t, err)
+
+	_, result := extractor.ExtractXPath(newTestContext(), []byte(testXML))
+
+	require.Error(t, result.(error))
+}